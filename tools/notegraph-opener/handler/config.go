@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config holds the user-configurable extension allow/block policy and
+// per-extension handler command overrides.
+type Config struct {
+	Allow    []string          `yaml:"allow"`
+	Block    []string          `yaml:"block"`
+	Handlers map[string]string `yaml:"handlers"`
+
+	allow map[string]bool
+	block map[string]bool
+}
+
+// defaultConfig returns the built-in allow/block policy used when no config
+// file is present, matching the original hard-coded behavior.
+func defaultConfig() *Config {
+	return &Config{
+		allow: allowedExtensions,
+		block: blockedExtensions,
+	}
+}
+
+// activeConfig is the config currently in effect. It starts out as the
+// built-in defaults; main wires it up to LoadConfig's result at startup.
+var activeConfig = defaultConfig()
+
+// InitConfig loads the on-disk config from its default location and makes it
+// active. Call once at startup; on error the built-in defaults remain active.
+func InitConfig() error {
+	cfg, err := LoadConfig(getConfigPath())
+	if err != nil {
+		return err
+	}
+	activeConfig = cfg
+	return nil
+}
+
+// getConfigPath returns the path to the YAML config file.
+func getConfigPath() string {
+	appData := os.Getenv("APPDATA")
+	if appData == "" {
+		appData = "."
+	}
+	return filepath.Join(appData, "notegraph-opener", "config.yaml")
+}
+
+// LoadConfig reads and parses the YAML config at path. If the file does not
+// exist, it returns the built-in defaults with no error. An allow or block
+// list left empty in the file falls back to its built-in default.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return defaultConfig(), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var raw Config
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	cfg := &Config{
+		Allow:    raw.Allow,
+		Block:    raw.Block,
+		Handlers: raw.Handlers,
+		allow:    toExtSet(raw.Allow),
+		block:    toExtSet(raw.Block),
+	}
+	if len(cfg.allow) == 0 {
+		cfg.allow = allowedExtensions
+	}
+	if len(cfg.block) == 0 {
+		cfg.block = blockedExtensions
+	}
+	return cfg, nil
+}
+
+// toExtSet lowercases exts into a lookup set.
+func toExtSet(exts []string) map[string]bool {
+	set := make(map[string]bool, len(exts))
+	for _, ext := range exts {
+		set[strings.ToLower(ext)] = true
+	}
+	return set
+}
+
+// handlerFor returns the command template configured for ext, if any.
+func (c *Config) handlerFor(ext string) (string, bool) {
+	cmd, ok := c.Handlers[strings.ToLower(ext)]
+	return cmd, ok
+}
@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadConfig_MissingFileReturnsDefaults(t *testing.T) {
+	cfg, err := LoadConfig(filepath.Join(t.TempDir(), "does-not-exist.yaml"))
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	if !cfg.allow[".pdf"] {
+		t.Errorf("expected default allow list to include .pdf")
+	}
+	if !cfg.block[".exe"] {
+		t.Errorf("expected default block list to include .exe")
+	}
+}
+
+func TestLoadConfig_CustomAllowBlockAndHandlers(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	content := "allow:\n  - .pdf\n  - .md\nblock:\n  - .exe\nhandlers:\n  .pdf: \"SumatraPDF.exe {path}\"\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+
+	if !cfg.allow[".md"] {
+		t.Errorf("expected custom allow list to include .md")
+	}
+	if cfg.allow[".docx"] {
+		t.Errorf("expected custom allow list to exclude .docx")
+	}
+
+	cmd, ok := cfg.handlerFor(".pdf")
+	if !ok || cmd != "SumatraPDF.exe {path}" {
+		t.Errorf("handlerFor(.pdf) = %q, %v, want %q, true", cmd, ok, "SumatraPDF.exe {path}")
+	}
+}
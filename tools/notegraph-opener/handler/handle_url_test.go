@@ -33,3 +33,82 @@ func TestHandleURL_OpensExistingFile(t *testing.T) {
 		t.Fatalf("opened path = %q, want %q", openedPath, expected)
 	}
 }
+
+func TestHandleURL_RevealAcceptsDirectoryLikePath(t *testing.T) {
+	originalReveal := revealFn
+	originalStat := statFn
+	t.Cleanup(func() {
+		revealFn = originalReveal
+		statFn = originalStat
+	})
+
+	var revealedPath string
+	revealFn = func(path string) error {
+		revealedPath = path
+		return nil
+	}
+	statFn = func(string) (os.FileInfo, error) {
+		return nil, nil
+	}
+
+	rawURL := "notegraph://reveal?path=C%3A%2FNotes"
+	if err := HandleURL(rawURL); err != nil {
+		t.Fatalf("HandleURL() error = %v", err)
+	}
+
+	expected := filepath.FromSlash("C:/Notes")
+	if revealedPath != expected {
+		t.Fatalf("revealed path = %q, want %q", revealedPath, expected)
+	}
+}
+
+func TestHandleURL_FolderOpensContainingDirectory(t *testing.T) {
+	originalOpen := openFileFn
+	originalStat := statFn
+	t.Cleanup(func() {
+		openFileFn = originalOpen
+		statFn = originalStat
+	})
+
+	var openedPath string
+	openFileFn = func(path string) error {
+		openedPath = path
+		return nil
+	}
+	statFn = func(string) (os.FileInfo, error) {
+		return nil, nil
+	}
+
+	rawURL := "notegraph://folder?path=C%3A%2FNotes%2Ffile.pdf"
+	if err := HandleURL(rawURL); err != nil {
+		t.Fatalf("HandleURL() error = %v", err)
+	}
+
+	expected := filepath.FromSlash("C:/Notes")
+	if openedPath != expected {
+		t.Fatalf("opened folder = %q, want %q", openedPath, expected)
+	}
+}
+
+func TestHandleURL_CopySendsPathToClipboard(t *testing.T) {
+	originalCopy := copyFn
+	t.Cleanup(func() {
+		copyFn = originalCopy
+	})
+
+	var copiedPath string
+	copyFn = func(text string) error {
+		copiedPath = text
+		return nil
+	}
+
+	rawURL := "notegraph://copy?path=C%3A%2FNotes%2Ffile.pdf"
+	if err := HandleURL(rawURL); err != nil {
+		t.Fatalf("HandleURL() error = %v", err)
+	}
+
+	expected := filepath.FromSlash("C:/Notes/file.pdf")
+	if copiedPath != expected {
+		t.Fatalf("copied path = %q, want %q", copiedPath, expected)
+	}
+}
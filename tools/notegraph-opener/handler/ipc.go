@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+)
+
+// Serve accepts connections on listener and handles each as a single
+// notegraph:// URL forwarded by another process instance, until ctx is
+// canceled or listener is closed.
+func Serve(ctx context.Context, listener net.Listener) error {
+	go func() {
+		<-ctx.Done()
+		listener.Close()
+	}()
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			select {
+			case <-ctx.Done():
+				return nil
+			default:
+				return err
+			}
+		}
+		go serveConn(conn)
+	}
+}
+
+// serveConn reads a single URL line from conn and hands it to HandleURL.
+func serveConn(conn net.Conn) {
+	defer conn.Close()
+
+	scanner := bufio.NewScanner(conn)
+	if !scanner.Scan() {
+		return
+	}
+	if err := HandleURL(scanner.Text()); err != nil {
+		LogError(err.Error())
+	}
+}
+
+// ForwardURL sends rawURL to an already-running instance over conn.
+func ForwardURL(conn net.Conn, rawURL string) error {
+	_, err := fmt.Fprintln(conn, rawURL)
+	return err
+}
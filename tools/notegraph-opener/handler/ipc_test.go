@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// pipeListener is a net.Listener backed by a single net.Pipe connection,
+// used to unit-test Serve without opening real sockets.
+type pipeListener struct {
+	conn   net.Conn
+	used   bool
+	closed chan struct{}
+}
+
+func newPipeListener(conn net.Conn) *pipeListener {
+	return &pipeListener{conn: conn, closed: make(chan struct{})}
+}
+
+func (l *pipeListener) Accept() (net.Conn, error) {
+	if !l.used {
+		l.used = true
+		return l.conn, nil
+	}
+	<-l.closed
+	return nil, errors.New("listener closed")
+}
+
+func (l *pipeListener) Close() error {
+	select {
+	case <-l.closed:
+	default:
+		close(l.closed)
+	}
+	return nil
+}
+
+func (l *pipeListener) Addr() net.Addr { return l.conn.LocalAddr() }
+
+func TestServe_HandlesForwardedURL(t *testing.T) {
+	originalOpen := openFileFn
+	originalStat := statFn
+	t.Cleanup(func() {
+		openFileFn = originalOpen
+		statFn = originalStat
+	})
+
+	handled := make(chan string, 1)
+	openFileFn = func(path string) error {
+		handled <- path
+		return nil
+	}
+	statFn = func(string) (os.FileInfo, error) {
+		return nil, nil
+	}
+
+	server, client := net.Pipe()
+	listener := newPipeListener(server)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go Serve(ctx, listener)
+
+	if err := ForwardURL(client, "notegraph://open?path=C%3A%2Ftest.txt"); err != nil {
+		t.Fatalf("ForwardURL() error = %v", err)
+	}
+
+	select {
+	case path := <-handled:
+		expected := filepath.FromSlash("C:/test.txt")
+		if path != expected {
+			t.Fatalf("opened path = %q, want %q", path, expected)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for forwarded URL to be handled")
+	}
+}
@@ -0,0 +1,32 @@
+//go:build !windows
+
+package handler
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+)
+
+// socketPath returns the unix socket path used for single-instance IPC,
+// under $XDG_RUNTIME_DIR (falling back to the OS temp dir).
+func socketPath() string {
+	dir := os.Getenv("XDG_RUNTIME_DIR")
+	if dir == "" {
+		dir = os.TempDir()
+	}
+	return filepath.Join(dir, "notegraph-opener.sock")
+}
+
+// ListenIPC becomes the single-instance listener for this platform.
+func ListenIPC() (net.Listener, error) {
+	path := socketPath()
+	os.Remove(path) // clear a stale socket left by a crashed instance
+	return net.Listen("unix", path)
+}
+
+// DialIPC connects to an already-running instance's IPC channel. It returns
+// an error if no instance is listening.
+func DialIPC() (net.Conn, error) {
+	return net.Dial("unix", socketPath())
+}
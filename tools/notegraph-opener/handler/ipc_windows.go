@@ -0,0 +1,23 @@
+//go:build windows
+
+package handler
+
+import (
+	"net"
+
+	winio "github.com/Microsoft/go-winio"
+)
+
+// pipeAddr is the well-known named pipe used for single-instance IPC.
+const pipeAddr = `\\.\pipe\notegraph-opener`
+
+// ListenIPC becomes the single-instance listener for this platform.
+func ListenIPC() (net.Listener, error) {
+	return winio.ListenPipe(pipeAddr, nil)
+}
+
+// DialIPC connects to an already-running instance's IPC channel. It returns
+// an error if no instance is listening.
+func DialIPC() (net.Conn, error) {
+	return winio.DialPipe(pipeAddr, nil)
+}
@@ -1,15 +1,31 @@
 package handler
 
 import (
+	"compress/gzip"
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"sync"
 	"time"
 )
 
-var logFile *os.File
+// maxLogSize is the size at which the active log file rotates.
+const maxLogSize = 5 * 1024 * 1024 // 5 MB
 
-// getLogPath returns the path to the log file.
+// maxLogArchives is how many rotated, gzip-compressed logs are retained
+// (log.1.txt.gz through log.5.txt.gz).
+const maxLogArchives = 5
+
+var (
+	logMu     sync.Mutex
+	logWriter io.Writer
+	logPath   string
+	logSize   int64
+)
+
+// getLogPath returns the path to the active log file.
 func getLogPath() string {
 	appData := os.Getenv("APPDATA")
 	if appData == "" {
@@ -18,58 +34,163 @@ func getLogPath() string {
 	return filepath.Join(appData, "notegraph-opener", "log.txt")
 }
 
-// initLog initializes the log file.
-func initLog() error {
-	if logFile != nil {
+// SetLogWriter overrides the destination log entries are written to and
+// disables file-based rotation. Tests use this to assert on log output
+// without touching %APPDATA%.
+func SetLogWriter(w io.Writer) {
+	logMu.Lock()
+	defer logMu.Unlock()
+	logWriter = w
+	logPath = ""
+}
+
+// initLogLocked opens the log file for appending and records its current
+// size so rotation triggers at the right point. Caller must hold logMu.
+func initLogLocked() error {
+	if logWriter != nil {
 		return nil
 	}
 
-	logPath := getLogPath()
-	logDir := filepath.Dir(logPath)
-
-	// Create directory if it doesn't exist
-	if err := os.MkdirAll(logDir, 0755); err != nil {
+	logPath = getLogPath()
+	if err := os.MkdirAll(filepath.Dir(logPath), 0755); err != nil {
 		return err
 	}
 
-	// Open log file in append mode
-	var err error
-	logFile, err = os.OpenFile(logPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if info, err := os.Stat(logPath); err == nil {
+		logSize = info.Size()
+	}
+
+	f, err := os.OpenFile(logPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
 	if err != nil {
 		return err
 	}
-
+	logWriter = f
 	return nil
 }
 
-// writeLog writes a log entry.
-func writeLog(level, message string) {
-	if err := initLog(); err != nil {
-		// If we can't log, just print to stderr
-		fmt.Fprintf(os.Stderr, "[%s] %s: %s\n", time.Now().Format("2006-01-02 15:04:05"), level, message)
+// LogFields writes a single structured JSON-line log entry with the given
+// level and fields, plus a timestamp and pid.
+func LogFields(level string, fields map[string]any) {
+	logMu.Lock()
+	defer logMu.Unlock()
+
+	if err := initLogLocked(); err != nil {
+		fmt.Fprintf(os.Stderr, "[%s] %s: %v\n", time.Now().Format(time.RFC3339), level, fields)
+		return
+	}
+
+	entry := make(map[string]any, len(fields)+3)
+	for k, v := range fields {
+		entry[k] = v
+	}
+	entry["ts"] = time.Now().Format(time.RFC3339)
+	entry["level"] = level
+	entry["pid"] = os.Getpid()
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to marshal log entry: %v\n", err)
+		return
+	}
+	line = append(line, '\n')
+
+	n, err := logWriter.Write(line)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to write log entry: %v\n", err)
+		return
+	}
+
+	logSize += int64(n)
+	rotateIfNeededLocked()
+}
+
+// rotateIfNeededLocked rotates the log file once it exceeds maxLogSize.
+// It's a no-op when the writer was overridden via SetLogWriter. Caller must
+// hold logMu.
+func rotateIfNeededLocked() {
+	if logPath == "" || logSize < maxLogSize {
+		return
+	}
+
+	f, ok := logWriter.(*os.File)
+	if !ok {
 		return
 	}
+	f.Close()
+
+	// Evict the oldest archive, then shift the rest up by one.
+	os.Remove(archivePath(maxLogArchives))
+	for i := maxLogArchives - 1; i >= 1; i-- {
+		src := archivePath(i)
+		if _, err := os.Stat(src); err == nil {
+			os.Rename(src, archivePath(i+1))
+		}
+	}
+
+	if err := compressLog(logPath, archivePath(1)); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to rotate log: %v\n", err)
+	}
+
+	newFile, err := os.OpenFile(logPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to reopen log after rotation: %v\n", err)
+		logWriter = nil
+		return
+	}
+	logWriter = newFile
+	logSize = 0
+}
+
+// archivePath returns the path for the nth retained archive, e.g.
+// log.1.txt.gz.
+func archivePath(n int) string {
+	return filepath.Join(filepath.Dir(logPath), fmt.Sprintf("log.%d.txt.gz", n))
+}
+
+// compressLog gzips src into dst and removes src.
+func compressLog(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
 
-	timestamp := time.Now().Format("2006-01-02 15:04:05")
-	logEntry := fmt.Sprintf("[%s] %s: %s\n", timestamp, level, message)
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
 
-	logFile.WriteString(logEntry)
+	gz := gzip.NewWriter(out)
+	if _, err := io.Copy(gz, in); err != nil {
+		gz.Close()
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		return err
+	}
+
+	return os.Remove(src)
 }
 
 // LogInfo logs an info message.
 func LogInfo(message string) {
-	writeLog("INFO", message)
+	LogFields("INFO", map[string]any{"msg": message})
 }
 
 // LogError logs an error message.
 func LogError(message string) {
-	writeLog("ERROR", message)
+	LogFields("ERROR", map[string]any{"msg": message})
 }
 
-// CloseLog closes the log file.
+// CloseLog closes the log file and sweeps stale archive extractions.
 func CloseLog() {
-	if logFile != nil {
-		logFile.Close()
-		logFile = nil
+	cleanupOldExtractions()
+
+	logMu.Lock()
+	defer logMu.Unlock()
+	if f, ok := logWriter.(*os.File); ok {
+		f.Close()
 	}
+	logWriter = nil
 }
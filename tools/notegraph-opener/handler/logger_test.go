@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestLogFields_WritesJSONLine(t *testing.T) {
+	var buf bytes.Buffer
+	originalWriter := logWriter
+	originalPath := logPath
+	t.Cleanup(func() {
+		logWriter = originalWriter
+		logPath = originalPath
+	})
+
+	SetLogWriter(&buf)
+
+	LogFields("INFO", map[string]any{"msg": "opened file", "path": "C:/test.pdf"})
+
+	var entry map[string]any
+	if err := json.Unmarshal(bytes.TrimRight(buf.Bytes(), "\n"), &entry); err != nil {
+		t.Fatalf("log output is not valid JSON: %v\noutput: %s", err, buf.String())
+	}
+
+	if entry["level"] != "INFO" {
+		t.Errorf("entry[level] = %v, want INFO", entry["level"])
+	}
+	if entry["msg"] != "opened file" {
+		t.Errorf("entry[msg] = %v, want %q", entry["msg"], "opened file")
+	}
+	if entry["path"] != "C:/test.pdf" {
+		t.Errorf("entry[path] = %v, want %q", entry["path"], "C:/test.pdf")
+	}
+	if _, ok := entry["ts"]; !ok {
+		t.Error("entry missing ts field")
+	}
+	if _, ok := entry["pid"]; !ok {
+		t.Error("entry missing pid field")
+	}
+}
+
+func TestLogInfoAndLogError_SetMsgAndLevel(t *testing.T) {
+	var buf bytes.Buffer
+	originalWriter := logWriter
+	originalPath := logPath
+	t.Cleanup(func() {
+		logWriter = originalWriter
+		logPath = originalPath
+	})
+
+	SetLogWriter(&buf)
+	LogInfo("hello")
+
+	var entry map[string]any
+	if err := json.Unmarshal(bytes.TrimRight(buf.Bytes(), "\n"), &entry); err != nil {
+		t.Fatalf("log output is not valid JSON: %v", err)
+	}
+	if entry["level"] != "INFO" || entry["msg"] != "hello" {
+		t.Errorf("entry = %v, want level=INFO msg=hello", entry)
+	}
+
+	buf.Reset()
+	LogError("boom")
+
+	if err := json.Unmarshal(bytes.TrimRight(buf.Bytes(), "\n"), &entry); err != nil {
+		t.Fatalf("log output is not valid JSON: %v", err)
+	}
+	if entry["level"] != "ERROR" || entry["msg"] != "boom" {
+		t.Errorf("entry = %v, want level=ERROR msg=boom", entry)
+	}
+}
@@ -5,36 +5,140 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"runtime"
 	"strings"
 )
 
-// HandleURL processes the notegraph:// URL and opens the file.
+// Opener launches a file using the host OS's default file association.
+type Opener interface {
+	Open(path string) error
+}
+
+var activeOpener Opener
+
+func init() {
+	activeOpener = newOpener(runtime.GOOS)
+}
+
+// newOpener selects the Opener implementation for the given GOOS value.
+func newOpener(goos string) Opener {
+	switch goos {
+	case "windows":
+		return windowsOpener{}
+	case "darwin":
+		return darwinOpener{}
+	default:
+		return linuxOpener{}
+	}
+}
+
+// openFileFn is the file-opening entry point; swapped out in tests.
+var openFileFn = func(path string) error {
+	ext := strings.ToLower(filepath.Ext(path))
+	if cmdTemplate, ok := activeConfig.handlerFor(ext); ok {
+		return runHandlerCommand(cmdTemplate, path)
+	}
+	return activeOpener.Open(path)
+}
+
+// runHandlerCommand runs a configured handler command template, substituting
+// "{path}" with the target file path.
+func runHandlerCommand(template, path string) error {
+	cmdStr := strings.ReplaceAll(template, "{path}", path)
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.Command("cmd", "/c", cmdStr)
+	} else {
+		cmd = exec.Command("sh", "-c", cmdStr)
+	}
+	cmd.Stdout = nil
+	cmd.Stderr = nil
+	return cmd.Run()
+}
+
+// statFn is the filesystem stat entry point; swapped out in tests.
+var statFn = os.Stat
+
+// HandleURL processes the notegraph:// URL and routes it to the action it
+// names: open, reveal, folder, or copy.
 func HandleURL(rawURL string) error {
 	// Parse URL
-	path, err := ParseURL(rawURL)
+	action, path, err := ParseURL(rawURL)
 	if err != nil {
 		return fmt.Errorf("failed to parse URL: %w", err)
 	}
 
-	LogInfo(fmt.Sprintf("Parsed path: %s", path))
+	LogFields("INFO", map[string]any{
+		"msg":    "parsed url",
+		"url":    rawURL,
+		"path":   path,
+		"action": string(action),
+	})
 
-	// Validate path
-	if err := ValidatePath(path); err != nil {
-		return fmt.Errorf("path validation failed: %w", err)
+	// When a signing secret is configured, every URL must carry a valid
+	// HMAC signature over its path; this closes the attack surface where
+	// any web page can fire notegraph://open?path=... at the user's disk.
+	if activeSecret != nil {
+		sig := ParseSignature(rawURL)
+		if sig == "" || !verifySignature(activeSecret, path, sig) {
+			return ErrBadSignature
+		}
 	}
 
-	// Normalize path separators for Windows
-	path = filepath.FromSlash(path)
+	switch action {
+	case ActionOpen:
+		return handleOpen(path)
+	case ActionReveal:
+		return handleReveal(path)
+	case ActionFolder:
+		return handleFolder(path)
+	case ActionCopy:
+		return handleCopy(path)
+	default:
+		return ErrInvalidHost
+	}
+}
+
+// handleOpen validates path (including archive subpaths) and opens it with
+// the platform default application or a configured handler override.
+func handleOpen(path string) error {
+	// An archive subpath (archive.zip!/member.pdf) validates the archive
+	// path for safety and the member path's extension, then extracts the
+	// member before falling through to the normal open flow.
+	if archivePath, memberPath, ok := splitArchivePath(path); ok {
+		if err := validatePathSafety(archivePath); err != nil {
+			return fmt.Errorf("path validation failed: %w", err)
+		}
+		if err := validateExtension(memberPath); err != nil {
+			return fmt.Errorf("path validation failed: %w", err)
+		}
+
+		archivePath = NormalizePath(archivePath)
+		extracted, err := extractMember(archivePath, memberPath)
+		if err != nil {
+			return fmt.Errorf("failed to extract archive member: %w", err)
+		}
+		path = extracted
+	} else {
+		// Validate path
+		if err := ValidatePath(path); err != nil {
+			return fmt.Errorf("path validation failed: %w", err)
+		}
+
+		// Normalize path separators for the host OS
+		path = NormalizePath(path)
+	}
 
 	// Check if file exists
-	if _, err := os.Stat(path); os.IsNotExist(err) {
+	if _, err := statFn(path); err != nil {
 		return fmt.Errorf("file not found: %s", path)
 	}
 
 	LogInfo(fmt.Sprintf("Opening file: %s", path))
 
-	// Open file with default application
-	if err := openFile(path); err != nil {
+	// Open file with its configured handler or the platform's default application
+	if err := openFileFn(path); err != nil {
 		return fmt.Errorf("failed to open file: %w", err)
 	}
 
@@ -42,9 +146,117 @@ func HandleURL(rawURL string) error {
 	return nil
 }
 
-// openFile opens a file with the default Windows application.
-// Uses "cmd /c start" which is the standard way to open files on Windows.
-func openFile(path string) error {
+// handleReveal highlights path in its containing folder. Unlike open, it
+// accepts directory paths, so it skips the extension check.
+func handleReveal(path string) error {
+	if err := validatePathSafety(path); err != nil {
+		return fmt.Errorf("path validation failed: %w", err)
+	}
+	path = NormalizePath(path)
+
+	if _, err := statFn(path); err != nil {
+		return fmt.Errorf("file not found: %s", path)
+	}
+
+	LogInfo(fmt.Sprintf("Revealing: %s", path))
+	if err := revealFn(path); err != nil {
+		return fmt.Errorf("failed to reveal path: %w", err)
+	}
+	return nil
+}
+
+// handleFolder opens the directory containing path. Unlike open, it skips
+// the extension check since it operates on the containing directory, not
+// path itself.
+func handleFolder(path string) error {
+	if err := validatePathSafety(path); err != nil {
+		return fmt.Errorf("path validation failed: %w", err)
+	}
+	folder := filepath.Dir(NormalizePath(path))
+
+	if _, err := statFn(folder); err != nil {
+		return fmt.Errorf("folder not found: %s", folder)
+	}
+
+	LogInfo(fmt.Sprintf("Opening containing folder: %s", folder))
+	if err := openFileFn(folder); err != nil {
+		return fmt.Errorf("failed to open folder: %w", err)
+	}
+	return nil
+}
+
+// handleCopy copies path to the system clipboard.
+func handleCopy(path string) error {
+	if err := ValidatePath(path); err != nil {
+		return fmt.Errorf("path validation failed: %w", err)
+	}
+	path = NormalizePath(path)
+
+	LogInfo(fmt.Sprintf("Copying path to clipboard: %s", path))
+	if err := copyFn(path); err != nil {
+		return fmt.Errorf("failed to copy path: %w", err)
+	}
+	return nil
+}
+
+// revealFn highlights path in its containing folder on Windows and macOS;
+// on other platforms it opens the containing folder without highlighting
+// path. Swapped out in tests.
+var revealFn = func(path string) error {
+	switch runtime.GOOS {
+	case "windows":
+		return exec.Command("explorer", "/select,"+path).Run()
+	case "darwin":
+		return exec.Command("open", "-R", path).Run()
+	default:
+		return openFileFn(filepath.Dir(path))
+	}
+}
+
+// copyFn copies text to the system clipboard; swapped out in tests.
+var copyFn = func(text string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "windows":
+		cmd = exec.Command("clip.exe")
+	case "darwin":
+		cmd = exec.Command("pbcopy")
+	default:
+		cmd = exec.Command("xclip", "-selection", "clipboard")
+	}
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	if _, err := stdin.Write([]byte(text)); err != nil {
+		return err
+	}
+	if err := stdin.Close(); err != nil {
+		return err
+	}
+	return cmd.Wait()
+}
+
+// NormalizePath converts a forward-slash path from a notegraph:// URL into
+// the host OS's native path form and cleans it.
+func NormalizePath(path string) string {
+	return filepath.Clean(filepath.FromSlash(path))
+}
+
+// windowsOpener opens files via the Windows shell's "start" verb.
+type windowsOpener struct{}
+
+func (windowsOpener) Open(path string) error {
+	return buildOpenCommand(path).Run()
+}
+
+// buildOpenCommand builds the "cmd /c start" invocation used to open path
+// with its associated Windows application.
+func buildOpenCommand(path string) *exec.Cmd {
 	// Quote the path to handle spaces and cmd metacharacters (&, |, ^, <, >)
 	// Without quotes, filenames like "R&D.pdf" would be misinterpreted by cmd
 	quotedPath := `"` + path + `"`
@@ -54,12 +266,25 @@ func openFile(path string) error {
 	cmd.Stdout = nil
 	cmd.Stderr = nil
 
+	return cmd
+}
+
+// darwinOpener opens files via the macOS "open" command.
+type darwinOpener struct{}
+
+func (darwinOpener) Open(path string) error {
+	cmd := exec.Command("open", path)
+	cmd.Stdout = nil
+	cmd.Stderr = nil
 	return cmd.Run()
 }
 
-// NormalizePath converts forward slashes to backslashes and cleans the path.
-func NormalizePath(path string) string {
-	// Convert forward slashes to backslashes for Windows
-	path = strings.ReplaceAll(path, "/", "\\")
-	return filepath.Clean(path)
+// linuxOpener opens files via the freedesktop.org "xdg-open" command.
+type linuxOpener struct{}
+
+func (linuxOpener) Open(path string) error {
+	cmd := exec.Command("xdg-open", path)
+	cmd.Stdout = nil
+	cmd.Stderr = nil
+	return cmd.Run()
 }
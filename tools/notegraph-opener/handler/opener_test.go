@@ -21,3 +21,24 @@ func TestBuildOpenCommand_QuotesPath(t *testing.T) {
 		t.Errorf("buildOpenCommand args = %v, want %v", cmd.Args, expectedArgs)
 	}
 }
+
+func TestNewOpener_SelectsByGOOS(t *testing.T) {
+	tests := []struct {
+		goos string
+		want Opener
+	}{
+		{"windows", windowsOpener{}},
+		{"darwin", darwinOpener{}},
+		{"linux", linuxOpener{}},
+		{"freebsd", linuxOpener{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.goos, func(t *testing.T) {
+			got := newOpener(tt.goos)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("newOpener(%s) = %T, want %T", tt.goos, got, tt.want)
+			}
+		})
+	}
+}
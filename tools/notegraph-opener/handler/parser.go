@@ -6,34 +6,45 @@ import (
 	"strings"
 )
 
+// Action identifies what a notegraph:// URL asks the handler to do.
+type Action string
+
+const (
+	ActionOpen   Action = "open"
+	ActionReveal Action = "reveal"
+	ActionFolder Action = "folder"
+	ActionCopy   Action = "copy"
+)
+
 var (
 	ErrInvalidScheme    = errors.New("invalid URL scheme: expected 'notegraph'")
-	ErrInvalidHost      = errors.New("invalid URL host: expected 'open'")
+	ErrInvalidHost      = errors.New("invalid URL host: expected one of open, reveal, folder, copy")
 	ErrMissingPath      = errors.New("missing 'path' parameter")
 	ErrEmptyPath        = errors.New("path parameter is empty")
 )
 
-// ParseURL parses a notegraph:// URL and returns the file path.
-// Expected format: notegraph://open?path=<url-encoded-path>
-func ParseURL(rawURL string) (string, error) {
+// ParseURL parses a notegraph:// URL and returns its action and file path.
+// Expected format: notegraph://<action>?path=<url-encoded-path>
+func ParseURL(rawURL string) (Action, string, error) {
 	// Handle URL without proper scheme prefix
 	if !strings.HasPrefix(rawURL, "notegraph://") {
-		return "", ErrInvalidScheme
+		return "", "", ErrInvalidScheme
 	}
 
 	parsed, err := url.Parse(rawURL)
 	if err != nil {
-		return "", err
+		return "", "", err
 	}
 
 	// Validate scheme
 	if parsed.Scheme != "notegraph" {
-		return "", ErrInvalidScheme
+		return "", "", ErrInvalidScheme
 	}
 
 	// Validate host (action)
-	if parsed.Host != "open" {
-		return "", ErrInvalidHost
+	action, ok := parseAction(parsed.Host)
+	if !ok {
+		return "", "", ErrInvalidHost
 	}
 
 	// Extract path parameter
@@ -43,10 +54,30 @@ func ParseURL(rawURL string) (string, error) {
 	if pathParam == "" {
 		// Check if path exists but is empty
 		if _, exists := query["path"]; exists {
-			return "", ErrEmptyPath
+			return "", "", ErrEmptyPath
 		}
-		return "", ErrMissingPath
+		return "", "", ErrMissingPath
 	}
 
-	return pathParam, nil
+	return action, pathParam, nil
+}
+
+// parseAction maps a URL host to its Action, if recognized.
+func parseAction(host string) (Action, bool) {
+	switch Action(host) {
+	case ActionOpen, ActionReveal, ActionFolder, ActionCopy:
+		return Action(host), true
+	default:
+		return "", false
+	}
+}
+
+// ParseSignature extracts the optional "sig" query parameter from a
+// notegraph:// URL, returning "" if it is absent or the URL can't be parsed.
+func ParseSignature(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return parsed.Query().Get("sig")
 }
@@ -34,11 +34,14 @@ func TestParseURL_ValidURL(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result, err := ParseURL(tt.url)
+			action, result, err := ParseURL(tt.url)
 			if err != nil {
 				t.Errorf("ParseURL() error = %v", err)
 				return
 			}
+			if action != ActionOpen {
+				t.Errorf("ParseURL() action = %v, want %v", action, ActionOpen)
+			}
 			if result != tt.expected {
 				t.Errorf("ParseURL() = %v, want %v", result, tt.expected)
 			}
@@ -46,6 +49,30 @@ func TestParseURL_ValidURL(t *testing.T) {
 	}
 }
 
+func TestParseURL_Actions(t *testing.T) {
+	tests := []struct {
+		url  string
+		want Action
+	}{
+		{"notegraph://open?path=C%3A%2Ftest.txt", ActionOpen},
+		{"notegraph://reveal?path=C%3A%2Ftest.txt", ActionReveal},
+		{"notegraph://folder?path=C%3A%2Ftest.txt", ActionFolder},
+		{"notegraph://copy?path=C%3A%2Ftest.txt", ActionCopy},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.want), func(t *testing.T) {
+			action, _, err := ParseURL(tt.url)
+			if err != nil {
+				t.Fatalf("ParseURL() error = %v", err)
+			}
+			if action != tt.want {
+				t.Errorf("ParseURL() action = %v, want %v", action, tt.want)
+			}
+		})
+	}
+}
+
 func TestParseURL_InvalidURL(t *testing.T) {
 	tests := []struct {
 		name string
@@ -71,7 +98,7 @@ func TestParseURL_InvalidURL(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			_, err := ParseURL(tt.url)
+			_, _, err := ParseURL(tt.url)
 			if err == nil {
 				t.Error("ParseURL() expected error, got nil")
 			}
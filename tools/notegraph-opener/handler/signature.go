@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+)
+
+// ErrBadSignature is returned when a notegraph:// URL's sig parameter does
+// not match the expected HMAC for its path.
+var ErrBadSignature = errors.New("invalid URL signature")
+
+// secretKeySize is the size, in bytes, of the auto-generated HMAC secret.
+const secretKeySize = 32
+
+// activeSecret is the HMAC secret currently in effect, loaded by
+// InitSignature. A nil secret means signature checking is disabled.
+var activeSecret []byte
+
+// getSecretPath returns the path to the HMAC secret file.
+func getSecretPath() string {
+	appData := os.Getenv("APPDATA")
+	if appData == "" {
+		appData = "."
+	}
+	return filepath.Join(appData, "notegraph-opener", "secret.key")
+}
+
+// InitSignature loads the HMAC secret from its default location, if one
+// exists. Call once at startup. If no secret file exists, signature
+// checking stays disabled and HandleURL behaves as before.
+func InitSignature() error {
+	secret, err := loadSecret(getSecretPath())
+	if err != nil {
+		return err
+	}
+	activeSecret = secret
+	return nil
+}
+
+// loadSecret reads the HMAC secret from path. If the file does not exist,
+// it returns (nil, nil): signing is not yet enabled.
+func loadSecret(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// ensureSecret reads the HMAC secret from path, generating and persisting a
+// new random secret the first time it's called with no secret file present.
+func ensureSecret(path string) ([]byte, error) {
+	secret, err := loadSecret(path)
+	if err != nil {
+		return nil, err
+	}
+	if secret != nil {
+		return secret, nil
+	}
+
+	secret = make([]byte, secretKeySize)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(path, secret, 0600); err != nil {
+		return nil, err
+	}
+	return secret, nil
+}
+
+// SignPath computes the hex-encoded HMAC-SHA256 signature of path, creating
+// the on-disk secret on first use.
+func SignPath(path string) (string, error) {
+	secret, err := ensureSecret(getSecretPath())
+	if err != nil {
+		return "", err
+	}
+	return signPath(secret, path), nil
+}
+
+// signPath computes the hex-encoded HMAC-SHA256 signature of path under
+// secret.
+func signPath(secret []byte, path string) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(path))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// verifySignature reports whether sig is the valid hex-encoded HMAC-SHA256
+// signature of path under secret.
+func verifySignature(secret []byte, path, sig string) bool {
+	expected, err := hex.DecodeString(sig)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(path))
+	return hmac.Equal(expected, mac.Sum(nil))
+}
@@ -0,0 +1,44 @@
+package handler
+
+import "testing"
+
+func TestSignAndVerifySignature(t *testing.T) {
+	secret := []byte("test-secret")
+	path := "C:/docs/file.pdf"
+
+	sig := signPath(secret, path)
+
+	if !verifySignature(secret, path, sig) {
+		t.Errorf("verifySignature() = false, want true for matching signature")
+	}
+	if verifySignature(secret, "C:/docs/other.pdf", sig) {
+		t.Errorf("verifySignature() = true, want false for a different path")
+	}
+	if verifySignature([]byte("wrong-secret"), path, sig) {
+		t.Errorf("verifySignature() = true, want false for a different secret")
+	}
+}
+
+func TestVerifySignature_InvalidHex(t *testing.T) {
+	if verifySignature([]byte("secret"), "C:/docs/file.pdf", "not-hex") {
+		t.Errorf("verifySignature() = true, want false for non-hex signature")
+	}
+}
+
+func TestParseSignature(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{"notegraph://open?path=C%3A%2Ftest.txt&sig=abc123", "abc123"},
+		{"notegraph://open?path=C%3A%2Ftest.txt", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			if got := ParseSignature(tt.url); got != tt.want {
+				t.Errorf("ParseSignature(%q) = %q, want %q", tt.url, got, tt.want)
+			}
+		})
+	}
+}
@@ -51,8 +51,28 @@ var blockedExtensions = map[string]bool{
 	".reg": true,
 }
 
+// isAbsolutePath reports whether path is an absolute Windows path
+// (C:/... or C:\...) or an absolute POSIX path (/...).
+func isAbsolutePath(path string) bool {
+	if strings.HasPrefix(path, "/") {
+		return true
+	}
+	return len(path) >= 3 && path[1] == ':' && (path[2] == '/' || path[2] == '\\')
+}
+
 // ValidatePath validates the file path for security.
 func ValidatePath(path string) error {
+	if err := validatePathSafety(path); err != nil {
+		return err
+	}
+	return validateExtension(path)
+}
+
+// validatePathSafety checks path for emptiness, traversal, and that it is
+// absolute, without checking its extension. Split out from ValidatePath so
+// archive member paths (relative to their archive) can skip the
+// absolute-path requirement while still reusing the extension check.
+func validatePathSafety(path string) error {
 	if path == "" {
 		return ErrEmptyPathValidation
 	}
@@ -62,22 +82,27 @@ func ValidatePath(path string) error {
 		return ErrPathTraversal
 	}
 
-	// Check for absolute path (Windows)
-	// Must start with drive letter like C:/ or C:\
-	if len(path) < 3 || path[1] != ':' || (path[2] != '/' && path[2] != '\\') {
+	// Check for absolute path: either a Windows drive letter (C:/ or C:\)
+	// or a POSIX absolute path (/home/...)
+	if !isAbsolutePath(path) {
 		return ErrRelativePath
 	}
 
-	// Get file extension (case insensitive)
+	return nil
+}
+
+// validateExtension checks path's extension against the active allow/block
+// policy.
+func validateExtension(path string) error {
 	ext := strings.ToLower(filepath.Ext(path))
 
 	// Check blocked extensions first
-	if blockedExtensions[ext] {
+	if activeConfig.block[ext] {
 		return ErrBlockedExtension
 	}
 
 	// Check allowed extensions
-	if !allowedExtensions[ext] {
+	if !activeConfig.allow[ext] {
 		return ErrUnknownExtension
 	}
 
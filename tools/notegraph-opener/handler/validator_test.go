@@ -77,6 +77,23 @@ func TestValidatePath_PathTraversal(t *testing.T) {
 	}
 }
 
+func TestValidatePath_POSIXAbsolutePaths(t *testing.T) {
+	allowedPaths := []string{
+		"/home/user/test.pdf",
+		"/Users/user/Documents/file.docx",
+		"/var/notes/photo.png",
+	}
+
+	for _, path := range allowedPaths {
+		t.Run(path, func(t *testing.T) {
+			err := ValidatePath(path)
+			if err != nil {
+				t.Errorf("ValidatePath(%s) should be allowed, got error: %v", path, err)
+			}
+		})
+	}
+}
+
 func TestValidatePath_InvalidPath(t *testing.T) {
 	invalidPaths := []string{
 		"",
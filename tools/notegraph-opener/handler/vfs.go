@@ -0,0 +1,198 @@
+package handler
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/bzip2"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ErrArchiveMemberNotFound is returned when the requested member does not
+// exist inside the archive.
+var ErrArchiveMemberNotFound = errors.New("archive member not found")
+
+// archiveSeparator splits an archive path from the member path within it,
+// e.g. "C:/refs/papers.zip!/2024/neurips.pdf".
+const archiveSeparator = "!/"
+
+// maxExtractionAge is how long an archive extraction's temp directory is
+// kept around before cleanupOldExtractions removes it.
+const maxExtractionAge = 24 * time.Hour
+
+// splitArchivePath splits path into (archivePath, memberPath, ok). ok is
+// false when path has no archive separator.
+func splitArchivePath(path string) (archivePath, memberPath string, ok bool) {
+	idx := strings.Index(path, archiveSeparator)
+	if idx == -1 {
+		return "", "", false
+	}
+	return path[:idx], path[idx+len(archiveSeparator):], true
+}
+
+// archiveKind returns "zip", "tar", "tar.gz", "tar.bz2", or "" if
+// archivePath is not a recognized archive type.
+func archiveKind(archivePath string) string {
+	lower := strings.ToLower(archivePath)
+	ext := filepath.Ext(lower)
+	switch ext {
+	case ".zip":
+		return "zip"
+	case ".tar":
+		return "tar"
+	case ".gz":
+		if filepath.Ext(strings.TrimSuffix(lower, ext)) == ".tar" {
+			return "tar.gz"
+		}
+	case ".bz2":
+		if filepath.Ext(strings.TrimSuffix(lower, ext)) == ".tar" {
+			return "tar.bz2"
+		}
+	}
+	return ""
+}
+
+// tempRoot is the base directory under which archive members are extracted.
+func tempRoot() string {
+	return filepath.Join(os.TempDir(), "notegraph-opener")
+}
+
+// extractionDir returns the temp directory used for extractions from
+// archivePath, keyed by a hash of its path so repeated opens reuse it.
+func extractionDir(archivePath string) string {
+	sum := sha256.Sum256([]byte(archivePath))
+	hash := hex.EncodeToString(sum[:])[:16]
+	return filepath.Join(tempRoot(), hash)
+}
+
+// extractMember extracts memberPath from the archive at archivePath into a
+// temp file and returns the extracted file's path.
+func extractMember(archivePath, memberPath string) (string, error) {
+	kind := archiveKind(archivePath)
+	if kind == "" {
+		return "", fmt.Errorf("unsupported archive type: %s", archivePath)
+	}
+
+	destDir := extractionDir(archivePath)
+	if err := os.MkdirAll(destDir, 0755); err != nil {
+		return "", err
+	}
+
+	destPath := filepath.Join(destDir, filepath.Base(memberPath))
+
+	var err error
+	if kind == "zip" {
+		err = extractZipMember(archivePath, memberPath, destPath)
+	} else {
+		err = extractTarMember(archivePath, memberPath, destPath, kind)
+	}
+	if err != nil {
+		return "", err
+	}
+	return destPath, nil
+}
+
+// extractZipMember extracts memberPath from a zip archive to destPath.
+func extractZipMember(archivePath, memberPath, destPath string) error {
+	r, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	member := strings.TrimPrefix(memberPath, "/")
+	for _, f := range r.File {
+		if f.Name != member {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return err
+		}
+		defer rc.Close()
+		return writeTempFile(destPath, rc)
+	}
+	return ErrArchiveMemberNotFound
+}
+
+// extractTarMember extracts memberPath from a tar/tar.gz/tar.bz2 archive to
+// destPath.
+func extractTarMember(archivePath, memberPath, destPath, kind string) error {
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	switch kind {
+	case "tar.gz":
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return err
+		}
+		defer gz.Close()
+		r = gz
+	case "tar.bz2":
+		r = bzip2.NewReader(f)
+	}
+
+	member := strings.TrimPrefix(memberPath, "/")
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if hdr.Name == member {
+			return writeTempFile(destPath, tr)
+		}
+	}
+	return ErrArchiveMemberNotFound
+}
+
+// writeTempFile copies r into a new file at destPath.
+func writeTempFile(destPath string, r io.Reader) error {
+	out, err := os.Create(destPath)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+	_, err = io.Copy(out, r)
+	return err
+}
+
+// cleanupOldExtractions removes archive-extraction temp directories older
+// than maxExtractionAge. Best-effort: errors are ignored.
+func cleanupOldExtractions() {
+	root := tempRoot()
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return
+	}
+
+	cutoff := time.Now().Add(-maxExtractionAge)
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		if info.ModTime().Before(cutoff) {
+			os.RemoveAll(filepath.Join(root, entry.Name()))
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package handler
+
+import "testing"
+
+func TestSplitArchivePath(t *testing.T) {
+	tests := []struct {
+		name        string
+		path        string
+		wantArchive string
+		wantMember  string
+		wantOk      bool
+	}{
+		{
+			name:        "zip member",
+			path:        "C:/refs/papers.zip!/2024/neurips.pdf",
+			wantArchive: "C:/refs/papers.zip",
+			wantMember:  "2024/neurips.pdf",
+			wantOk:      true,
+		},
+		{
+			name:   "plain path",
+			path:   "C:/refs/paper.pdf",
+			wantOk: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			archive, member, ok := splitArchivePath(tt.path)
+			if ok != tt.wantOk {
+				t.Fatalf("splitArchivePath(%q) ok = %v, want %v", tt.path, ok, tt.wantOk)
+			}
+			if !ok {
+				return
+			}
+			if archive != tt.wantArchive || member != tt.wantMember {
+				t.Errorf("splitArchivePath(%q) = (%q, %q), want (%q, %q)", tt.path, archive, member, tt.wantArchive, tt.wantMember)
+			}
+		})
+	}
+}
+
+func TestArchiveKind(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"papers.zip", "zip"},
+		{"backup.tar", "tar"},
+		{"backup.tar.gz", "tar.gz"},
+		{"backup.tar.bz2", "tar.bz2"},
+		{"notes.pdf", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got := archiveKind(tt.path); got != tt.want {
+				t.Errorf("archiveKind(%q) = %q, want %q", tt.path, got, tt.want)
+			}
+		})
+	}
+}
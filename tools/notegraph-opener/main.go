@@ -1,7 +1,9 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"net/url"
 	"os"
 
 	"notegraph-opener/handler"
@@ -10,17 +12,81 @@ import (
 func main() {
 	defer handler.CloseLog()
 
+	if err := handler.InitConfig(); err != nil {
+		handler.LogError(fmt.Sprintf("failed to load config, using defaults: %v", err))
+	}
+	if err := handler.InitSignature(); err != nil {
+		handler.LogError(fmt.Sprintf("failed to load signing secret: %v", err))
+	}
+
 	if len(os.Args) < 2 {
 		fmt.Println("Usage: notegraph-opener <url>")
+		fmt.Println("       notegraph-opener sign <path>")
 		fmt.Println("Example: notegraph-opener notegraph://open?path=C:/test.txt")
 		os.Exit(1)
 	}
 
-	url := os.Args[1]
+	if os.Args[1] == "sign" {
+		signPathArg()
+		return
+	}
+
+	rawURL := os.Args[1]
+
+	// If another instance is already listening, forward the URL to it and
+	// exit immediately rather than paying process-spawn latency per click.
+	if conn, err := handler.DialIPC(); err == nil {
+		defer conn.Close()
+		if err := handler.ForwardURL(conn, rawURL); err != nil {
+			handler.LogError(err.Error())
+			os.Exit(1)
+		}
+		return
+	}
+
+	listener, err := handler.ListenIPC()
+	if err != nil {
+		// No instance to forward to and we can't become the listener
+		// either (e.g. the socket or pipe cannot be created) - just handle
+		// this URL directly.
+		handleURL(rawURL)
+		return
+	}
+	defer listener.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go handler.Serve(ctx, listener)
+
+	handleURL(rawURL)
 
-	if err := handler.HandleURL(url); err != nil {
+	// Stay alive to service URLs forwarded by future invocations.
+	select {}
+}
+
+func handleURL(rawURL string) {
+	if err := handler.HandleURL(rawURL); err != nil {
 		handler.LogError(err.Error())
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 }
+
+// signPathArg implements the "sign <path>" subcommand: it prints a
+// notegraph:// URL with a valid sig parameter for the given path, ready to
+// paste into a note-taking tool.
+func signPathArg() {
+	if len(os.Args) < 3 {
+		fmt.Fprintln(os.Stderr, "Usage: notegraph-opener sign <path>")
+		os.Exit(1)
+	}
+
+	path := os.Args[2]
+	sig, err := handler.SignPath(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("notegraph://open?path=%s&sig=%s\n", url.QueryEscape(path), sig)
+}